@@ -0,0 +1,181 @@
+package llm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// FixtureMode controls how the manager uses recorded LLM interactions
+type FixtureMode string
+
+const (
+	FixtureModeOff    FixtureMode = ""       // Talk to the live model
+	FixtureModeRecord FixtureMode = "record" // Talk to the live model and capture request→response pairs
+	FixtureModeReplay FixtureMode = "replay" // Serve responses from the fixture file, never touch the model
+)
+
+// Fixture is a single recorded request→response pair
+type Fixture struct {
+	Hash       string    `json:"hash"`
+	Purpose    Purpose   `json:"purpose"`
+	Request    Request   `json:"request"`
+	Response   Response  `json:"response"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// FixtureStore holds recorded interactions and persists them as JSON
+type FixtureStore struct {
+	path     string
+	mode     FixtureMode
+	fixtures map[string]Fixture
+	order    []string // Preserve recording order for stable files
+	mu       sync.RWMutex
+}
+
+// NewFixtureStore creates a fixture store backed by the given file.
+// In replay mode the file must exist; in record mode it is created on first save.
+func NewFixtureStore(path string, mode FixtureMode) (*FixtureStore, error) {
+	if mode != FixtureModeRecord && mode != FixtureModeReplay {
+		return nil, fmt.Errorf("invalid fixture mode: %q", mode)
+	}
+
+	store := &FixtureStore{
+		path:     path,
+		mode:     mode,
+		fixtures: make(map[string]Fixture),
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) && mode == FixtureModeRecord {
+			return store, nil
+		}
+		return nil, fmt.Errorf("failed to read fixture file: %w", err)
+	}
+
+	var fixtures []Fixture
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		return nil, fmt.Errorf("failed to parse fixture file: %w", err)
+	}
+
+	for _, f := range fixtures {
+		if _, exists := store.fixtures[f.Hash]; !exists {
+			store.order = append(store.order, f.Hash)
+		}
+		store.fixtures[f.Hash] = f
+	}
+
+	return store, nil
+}
+
+// Mode returns the store's fixture mode
+func (s *FixtureStore) Mode() FixtureMode {
+	return s.mode
+}
+
+// Path returns the fixture file path
+func (s *FixtureStore) Path() string {
+	return s.path
+}
+
+// Len returns the number of recorded fixtures
+func (s *FixtureStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.fixtures)
+}
+
+// Lookup returns the recorded response for a request, if any
+func (s *FixtureStore) Lookup(purpose Purpose, req Request) (*Response, bool) {
+	hash := HashRequest(purpose, req)
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	f, ok := s.fixtures[hash]
+	if !ok {
+		return nil, false
+	}
+	resp := f.Response
+	return &resp, true
+}
+
+// Record stores a request→response pair and writes the fixture file
+func (s *FixtureStore) Record(purpose Purpose, req Request, resp *Response) error {
+	if resp == nil {
+		return nil
+	}
+
+	hash := HashRequest(purpose, req)
+
+	s.mu.Lock()
+	if _, exists := s.fixtures[hash]; !exists {
+		s.order = append(s.order, hash)
+	}
+	s.fixtures[hash] = Fixture{
+		Hash:       hash,
+		Purpose:    purpose,
+		Request:    req,
+		Response:   *resp,
+		RecordedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return s.Save()
+}
+
+// Save writes all fixtures to disk in recording order
+func (s *FixtureStore) Save() error {
+	s.mu.RLock()
+	fixtures := make([]Fixture, 0, len(s.order))
+	for _, hash := range s.order {
+		fixtures = append(fixtures, s.fixtures[hash])
+	}
+	s.mu.RUnlock()
+
+	data, err := json.MarshalIndent(fixtures, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal fixtures: %w", err)
+	}
+
+	if dir := filepath.Dir(s.path); dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create fixture directory: %w", err)
+		}
+	}
+
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write fixture file: %w", err)
+	}
+
+	return nil
+}
+
+// HashRequest returns a stable hash identifying a request for a purpose.
+// Streaming is excluded since it does not change the content of the reply.
+func HashRequest(purpose Purpose, req Request) string {
+	key := struct {
+		Purpose     Purpose        `json:"purpose"`
+		Messages    []Message      `json:"messages"`
+		Temperature float64        `json:"temperature"`
+		MaxTokens   int            `json:"max_tokens"`
+		Options     map[string]any `json:"options,omitempty"`
+	}{
+		Purpose:     purpose,
+		Messages:    req.Messages,
+		Temperature: req.Temperature,
+		MaxTokens:   req.MaxTokens,
+		Options:     req.Options,
+	}
+
+	// json.Marshal sorts map keys, so the encoding is deterministic
+	data, _ := json.Marshal(key)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,110 @@
+package llm
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixtureRecordThenReplay(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fixtures", "session.json")
+
+	calls := 0
+	live := &mockClient{
+		model:     "code-model",
+		provider:  "mock",
+		available: true,
+		generateFunc: func(ctx context.Context, req Request) (*Response, error) {
+			calls++
+			return &Response{
+				Content:    "reply to " + req.Messages[len(req.Messages)-1].Content,
+				Model:      "code-model",
+				TokensUsed: 7,
+			}, nil
+		},
+	}
+
+	requests := []Request{
+		{Messages: []Message{{Role: "system", Content: "sys"}, {Role: "user", Content: "write add()"}}},
+		{Messages: []Message{{Role: "user", Content: "write tests"}}, Temperature: 0.2},
+	}
+
+	// Record against the live client
+	recordStore, err := NewFixtureStore(path, FixtureModeRecord)
+	require.NoError(t, err)
+
+	recorder := NewManager()
+	defer recorder.Stop()
+	recorder.clients[PurposeCode] = live
+	recorder.SetFixtureStore(recordStore)
+
+	var recorded []*Response
+	for _, req := range requests {
+		resp, err := recorder.Generate(context.Background(), PurposeCode, req)
+		require.NoError(t, err)
+		recorded = append(recorded, resp)
+	}
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, 2, recordStore.Len())
+
+	_, err = os.Stat(path)
+	require.NoError(t, err, "fixture file should be written")
+
+	// Replay with no clients registered at all
+	replayStore, err := NewFixtureStore(path, FixtureModeReplay)
+	require.NoError(t, err)
+
+	replayer := NewManager()
+	defer replayer.Stop()
+	replayer.SetFixtureStore(replayStore)
+
+	for i, req := range requests {
+		resp, err := replayer.Generate(context.Background(), PurposeCode, req)
+		require.NoError(t, err)
+		assert.Equal(t, recorded[i], resp)
+	}
+	assert.Equal(t, 2, calls, "replay must not hit the live client")
+}
+
+func TestFixtureReplayMiss(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty.json")
+	require.NoError(t, os.WriteFile(path, []byte("[]"), 0644))
+
+	store, err := NewFixtureStore(path, FixtureModeReplay)
+	require.NoError(t, err)
+
+	manager := NewManager()
+	defer manager.Stop()
+	manager.SetFixtureStore(store)
+
+	_, err = manager.Generate(context.Background(), PurposeChat, Request{
+		Messages: []Message{{Role: "user", Content: "unseen"}},
+	})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no recorded fixture")
+}
+
+func TestFixtureReplayRequiresFile(t *testing.T) {
+	_, err := NewFixtureStore(filepath.Join(t.TempDir(), "missing.json"), FixtureModeReplay)
+	assert.Error(t, err)
+
+	_, err = NewFixtureStore("x.json", FixtureModeOff)
+	assert.Error(t, err)
+}
+
+func TestHashRequestStable(t *testing.T) {
+	req := Request{
+		Messages: []Message{{Role: "user", Content: "hello"}},
+		Options:  map[string]any{"b": 2, "a": 1},
+	}
+	streamed := req
+	streamed.Stream = true
+
+	assert.Equal(t, HashRequest(PurposeChat, req), HashRequest(PurposeChat, req))
+	assert.Equal(t, HashRequest(PurposeChat, req), HashRequest(PurposeChat, streamed))
+	assert.NotEqual(t, HashRequest(PurposeChat, req), HashRequest(PurposeCode, req))
+}
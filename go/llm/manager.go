@@ -23,6 +23,7 @@ type Manager struct {
 	clients   map[Purpose]Client          // Registered clients (may not be loaded)
 	configs   map[Purpose]Config          // Configurations
 	instances map[Purpose]*ModelInstance  // Active instances with ref counting
+	fixtures  *FixtureStore               // Optional record/replay of interactions (tests)
 	mu        sync.RWMutex
 	ctx       context.Context
 	cancel    context.CancelFunc
@@ -110,9 +111,49 @@ func (m *Manager) GetClient(purpose Purpose) (Client, error) {
 	return nil, fmt.Errorf("no LLM available for purpose: %s", purpose)
 }
 
+// SetFixtureStore enables recording or replaying of Generate calls.
+// Pass nil to go back to talking to the live model only.
+func (m *Manager) SetFixtureStore(store *FixtureStore) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fixtures = store
+}
+
+// GetFixtureStore returns the active fixture store, or nil if none is set
+func (m *Manager) GetFixtureStore() *FixtureStore {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.fixtures
+}
+
 // Generate sends a request to the appropriate LLM based on purpose
 // If the requested LLM is not available, it will try fallback options
 func (m *Manager) Generate(ctx context.Context, purpose Purpose, req Request) (*Response, error) {
+	fixtures := m.GetFixtureStore()
+	if fixtures != nil && fixtures.Mode() == FixtureModeReplay {
+		if resp, ok := fixtures.Lookup(purpose, req); ok {
+			return resp, nil
+		}
+		return nil, fmt.Errorf("no recorded fixture for %s request (hash %s) in %s",
+			purpose, HashRequest(purpose, req)[:12], fixtures.Path())
+	}
+
+	resp, err := m.generateLive(ctx, purpose, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if fixtures != nil && fixtures.Mode() == FixtureModeRecord {
+		if err := fixtures.Record(purpose, req, resp); err != nil {
+			return nil, fmt.Errorf("failed to record fixture: %w", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// generateLive sends the request to a registered client, with chat fallback
+func (m *Manager) generateLive(ctx context.Context, purpose Purpose, req Request) (*Response, error) {
 	client, err := m.GetClient(purpose)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package setup
 import (
 	"context"
 	"fmt"
+	"os"
 
 	code_intelligence "wilson/capabilities/code_intelligence"
 	"wilson/capabilities/web"
@@ -51,6 +52,20 @@ func InitializeLLMManager(ctx context.Context, cfg *config.Config) *llm.Manager
 		}
 	}
 
+	// Optional record/replay of LLM interactions for hermetic test runs
+	if path := os.Getenv("WILSON_LLM_FIXTURES"); path != "" {
+		mode := llm.FixtureMode(os.Getenv("WILSON_LLM_FIXTURE_MODE"))
+		if mode == llm.FixtureModeOff {
+			mode = llm.FixtureModeReplay
+		}
+		store, err := llm.NewFixtureStore(path, mode)
+		if err != nil {
+			fmt.Printf("Warning: Failed to load LLM fixtures: %v\n", err)
+		} else {
+			manager.SetFixtureStore(store)
+		}
+	}
+
 	// Set the LLM manager for tools that need it
 	web.SetLLMManager(manager)
 	code_intelligence.SetLLMManager(manager)